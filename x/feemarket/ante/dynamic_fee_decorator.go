@@ -0,0 +1,52 @@
+package ante
+
+import (
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// FeeMarketKeeper is the subset of the feemarket keeper this decorator
+// needs, kept narrow to avoid an import cycle between the EVM ante handler
+// and the feemarket keeper package.
+type FeeMarketKeeper interface {
+	AdjustDynamicBaseFeePerGas(ctx sdk.Context, blockGasUsed uint64) (sdkmath.LegacyDec, error)
+}
+
+// DynamicBaseFeeDecorator updates the feemarket's in-block dynamic base fee
+// after every transaction, based on the cumulative gas used so far this
+// block, so that successive transactions in the same block see
+// progressively higher fees under congestion instead of all being priced
+// off the same EndBlock-computed base fee.
+//
+// It should be placed after the EVM's gas-consuming decorators in the ante
+// chain, since it reads the block gas meter after the current transaction's
+// gas has already been deducted from it.
+type DynamicBaseFeeDecorator struct {
+	feeMarketKeeper FeeMarketKeeper
+}
+
+// NewDynamicBaseFeeDecorator creates a DynamicBaseFeeDecorator.
+func NewDynamicBaseFeeDecorator(feeMarketKeeper FeeMarketKeeper) DynamicBaseFeeDecorator {
+	return DynamicBaseFeeDecorator{feeMarketKeeper: feeMarketKeeper}
+}
+
+// AnteHandle implements sdk.AnteDecorator.
+func (d DynamicBaseFeeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	newCtx, err := next(ctx, tx, simulate)
+	if err != nil {
+		return newCtx, err
+	}
+
+	if newCtx.BlockGasMeter() == nil {
+		return newCtx, nil
+	}
+
+	// A failure here (e.g. TargetGasUsedPerBlock misconfigured as zero)
+	// reflects a feemarket param problem, not anything wrong with this
+	// transaction, so it's logged rather than propagated as a tx failure.
+	if _, err := d.feeMarketKeeper.AdjustDynamicBaseFeePerGas(newCtx, newCtx.BlockGasMeter().GasConsumedToLimit()); err != nil {
+		newCtx.Logger().Error("failed to adjust dynamic base fee per gas", "error", err.Error())
+	}
+
+	return newCtx, nil
+}
@@ -0,0 +1,28 @@
+package ante
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// HandlerOptions bundles the keepers the feemarket ante decorators need to
+// be constructed.
+type HandlerOptions struct {
+	FeeMarketKeeper FeeMarketKeeper
+}
+
+// FeeMarketDecorators returns the feemarket-specific ante decorators, in the
+// order they must run, for splicing into the EVM ante handler chain after
+// its own gas-consuming decorators (see the placement note on
+// DynamicBaseFeeDecorator).
+//
+// TODO(app wiring): nothing in this module's tree calls this yet. The app's
+// top-level EVM ante handler assembly must append this slice to its own
+// decorator chain - until that one-line change lands, AdjustDynamicBaseFeePerGas
+// never runs during tx processing and the dynamic base fee stays frozen at
+// whatever EndBlock last set it. Do not treat the existence of this function
+// as that wiring having happened.
+func FeeMarketDecorators(options HandlerOptions) []sdk.AnteDecorator {
+	return []sdk.AnteDecorator{
+		NewDynamicBaseFeeDecorator(options.FeeMarketKeeper),
+	}
+}
@@ -1,6 +1,8 @@
 package keeper
 
 import (
+	"strconv"
+
 	"github.com/cosmos/evm/x/feemarket/types"
 
 	"github.com/cosmos/cosmos-sdk/telemetry"
@@ -11,6 +13,8 @@ import (
 // The EVM end block logic doesn't update the validator set, thus it returns
 // an empty slice.
 func (k *Keeper) EndBlock(ctx sdk.Context) error {
+	previousBaseFee := k.GetParams(ctx).BaseFee
+
 	baseFee := k.CalculateBaseFee(ctx)
 
 	// return immediately if base fee is nil
@@ -19,6 +23,7 @@ func (k *Keeper) EndBlock(ctx sdk.Context) error {
 	}
 
 	k.SetBaseFee(ctx, baseFee)
+	k.recordFeeHistory(ctx, baseFee)
 
 	defer func() {
 		floatBaseFee, err := baseFee.Float64()
@@ -31,12 +36,37 @@ func (k *Keeper) EndBlock(ctx sdk.Context) error {
 	}()
 
 	// Store current base fee in event
-	ctx.EventManager().EmitEvents(sdk.Events{
+	events := sdk.Events{
 		sdk.NewEvent(
 			types.EventTypeFeeMarket,
 			sdk.NewAttribute(types.AttributeKeyBaseFee, baseFee.String()),
 		),
-	})
+	}
+
+	// Emit the richer fee-dynamics event so wallets/monitoring can observe
+	// the direction of the move and whether the MinGasPrice floor absorbed
+	// it, without having to diff consecutive base_fee events themselves.
+	if !previousBaseFee.IsNil() {
+		minGasPrice := k.GetParams(ctx).MinGasPrice
+		// floorHit reports whether MinGasPrice is currently constraining the
+		// base fee, not just whether it was newly hit this block - a chain
+		// steadily pinned at the floor should keep reporting true every block.
+		floorHit := !minGasPrice.IsNil() && baseFee.Equal(minGasPrice)
+
+		events = append(events, sdk.NewEvent(
+			types.EventTypeFeeMarket,
+			sdk.NewAttribute(types.AttributeKeyBaseFeeDelta, baseFee.Sub(previousBaseFee).String()),
+			sdk.NewAttribute(types.AttributeKeyMinGasPriceFloorHit, strconv.FormatBool(floorHit)),
+		))
+
+		floorHitGauge := float32(0)
+		if floorHit {
+			floorHitGauge = 1
+		}
+		telemetry.SetGauge(floorHitGauge, "feemarket", "min_gas_price_floor_hit")
+	}
+
+	ctx.EventManager().EmitEvents(events)
 
 	return nil
 }
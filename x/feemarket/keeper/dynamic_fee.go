@@ -0,0 +1,96 @@
+package keeper
+
+import (
+	"errors"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/evm/x/feemarket/types"
+)
+
+// GetDynamicBaseFeePerGas returns the base fee as adjusted so far during the
+// current block by AdjustDynamicBaseFeePerGas. It falls back to the
+// persisted base fee from the previous block's EndBlock if no intra-block
+// adjustment has happened yet.
+func (k Keeper) GetDynamicBaseFeePerGas(ctx sdk.Context) sdkmath.LegacyDec {
+	store := ctx.TransientStore(k.transientKey)
+	bz := store.Get(types.KeyPrefixDynamicBaseFee)
+	if len(bz) == 0 {
+		return k.GetBaseFee(ctx)
+	}
+
+	fee := sdkmath.LegacyDec{}
+	if err := fee.Unmarshal(bz); err != nil {
+		return k.GetBaseFee(ctx)
+	}
+	return fee
+}
+
+// setDynamicBaseFeePerGas stores fee in the transient store so that
+// subsequent transactions within the same block observe the adjusted fee.
+// The value is discarded at Commit and reconciled by the next EndBlock's
+// CalculateBaseFee.
+func (k Keeper) setDynamicBaseFeePerGas(ctx sdk.Context, fee sdkmath.LegacyDec) error {
+	store := ctx.TransientStore(k.transientKey)
+	bz, err := fee.Marshal()
+	if err != nil {
+		return err
+	}
+	store.Set(types.KeyPrefixDynamicBaseFee, bz)
+	return nil
+}
+
+// AdjustDynamicBaseFeePerGas updates the in-block base fee based on the
+// cumulative gas used so far this block, rather than waiting for EndBlock.
+// It computes
+//
+//	newFee = currentFee * (1 + k*(gasUsed-target)/target)
+//
+// where k is 1/BaseFeeChangeDenominator, and clamps the result between
+// MinGasPrice and MaximumFeePerGas. The adjusted fee is written to a
+// transient key so intra-block reads (e.g. the EVM ante handler pricing the
+// next transaction) see it immediately; EndBlock's CalculateBaseFee remains
+// the final reconciliation for the persisted base fee.
+func (k Keeper) AdjustDynamicBaseFeePerGas(ctx sdk.Context, blockGasUsed uint64) (sdkmath.LegacyDec, error) {
+	params := k.GetParams(ctx)
+
+	targetGasUsed := params.TargetGasUsedPerBlock
+	if targetGasUsed == 0 {
+		return sdkmath.LegacyDec{}, errors.New("target gas used per block cannot be zero")
+	}
+
+	currentFee := k.GetDynamicBaseFeePerGas(ctx)
+	if currentFee.IsNil() {
+		return sdkmath.LegacyDec{}, errors.New("no base fee available to adjust")
+	}
+
+	target := sdkmath.NewIntFromUint64(targetGasUsed)
+	gasUsed := sdkmath.NewIntFromUint64(blockGasUsed)
+	denominator := sdkmath.NewIntFromUint64(uint64(params.BaseFeeChangeDenominator))
+
+	adjustmentRatio := sdkmath.LegacyNewDecFromInt(gasUsed.Sub(target)).QuoInt(target).QuoInt(denominator)
+	newFee := currentFee.Mul(sdkmath.LegacyOneDec().Add(adjustmentRatio))
+
+	if newFee.LT(params.MinGasPrice) {
+		newFee = params.MinGasPrice
+	}
+	if !params.MaximumFeePerGas.IsNil() && newFee.GT(params.MaximumFeePerGas) {
+		newFee = params.MaximumFeePerGas
+	}
+
+	if err := k.setDynamicBaseFeePerGas(ctx, newFee); err != nil {
+		return sdkmath.LegacyDec{}, err
+	}
+
+	defer func() {
+		floatFee, err := newFee.Float64()
+		if err != nil {
+			return
+		}
+		telemetry.SetGauge(float32(floatFee), "feemarket", "dynamic_base_fee")
+	}()
+
+	return newFee, nil
+}
@@ -0,0 +1,114 @@
+package keeper_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/evm/testutil/integration/os/network"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestAdjustDynamicBaseFeePerGas(t *testing.T) {
+	var (
+		nw  *network.UnitTestNetwork
+		ctx sdk.Context
+	)
+
+	testCases := []struct {
+		name          string
+		targetGasUsed uint64
+		maxFeePerGas  sdkmath.LegacyDec
+		minGasPrice   sdkmath.LegacyDec
+		blockGasUsed  uint64
+		expError      bool
+		expFeeIsMax   bool
+		expFeeIsFloor bool
+		expFee        func() sdkmath.LegacyDec
+	}{
+		{
+			name:          "zero target gas used returns an error",
+			targetGasUsed: 0,
+			maxFeePerGas:  sdkmath.LegacyDec{},
+			minGasPrice:   sdkmath.LegacyZeroDec(),
+			blockGasUsed:  1000,
+			expError:      true,
+		},
+		{
+			// currentFee starts at the unit test network's BaseFee
+			// (875,000,000, not DefaultParams' 1,000,000,000); with
+			// target 1000 and gasUsed 2000, adjustmentRatio = (2000-1000)/1000/8
+			// = 0.125, so newFee = 875,000,000 * 1.125 = 984,375,000. MinGasPrice
+			// is kept well below that so the floor clamp can't mask the result.
+			name:          "gas used above target increases the fee",
+			targetGasUsed: 1000,
+			maxFeePerGas:  sdkmath.LegacyDec{},
+			minGasPrice:   sdkmath.LegacyZeroDec(),
+			blockGasUsed:  2000,
+			expFee:        func() sdkmath.LegacyDec { return sdkmath.LegacyNewDec(984375000) },
+		},
+		{
+			name:          "fee is clamped at MaximumFeePerGas under heavy congestion",
+			targetGasUsed: 1000,
+			maxFeePerGas:  sdkmath.LegacyNewDec(1),
+			minGasPrice:   sdkmath.LegacyZeroDec(),
+			blockGasUsed:  1_000_000,
+			expFeeIsMax:   true,
+		},
+		{
+			name:          "gas used below target decreases the fee but not below MinGasPrice",
+			targetGasUsed: 1_000_000,
+			maxFeePerGas:  sdkmath.LegacyDec{},
+			minGasPrice:   sdkmath.LegacyNewDec(1_000_000_000),
+			blockGasUsed:  0,
+			expFeeIsFloor: true,
+		},
+		{
+			// blockGasUsed at math.MaxUint64 must not overflow or panic when
+			// computing the adjustment ratio; MaximumFeePerGas still clamps
+			// the astronomically large unclamped result as usual.
+			name:          "blockGasUsed at MaxUint64 does not overflow and is clamped at MaximumFeePerGas",
+			targetGasUsed: 1000,
+			maxFeePerGas:  sdkmath.LegacyNewDec(2_000_000_000),
+			minGasPrice:   sdkmath.LegacyZeroDec(),
+			blockGasUsed:  math.MaxUint64,
+			expFeeIsMax:   true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			nw = network.NewUnitTestNetwork()
+			ctx = nw.GetContext()
+
+			params := nw.App.FeeMarketKeeper.GetParams(ctx)
+			params.TargetGasUsedPerBlock = tc.targetGasUsed
+			params.MaximumFeePerGas = tc.maxFeePerGas
+			params.MinGasPrice = tc.minGasPrice
+			err := nw.App.FeeMarketKeeper.SetParams(ctx, params)
+			require.NoError(t, err)
+
+			fee, err := nw.App.FeeMarketKeeper.AdjustDynamicBaseFeePerGas(ctx, tc.blockGasUsed)
+			if tc.expError {
+				require.Error(t, err, tc.name)
+				return
+			}
+			require.NoError(t, err, tc.name)
+
+			switch {
+			case tc.expFeeIsMax:
+				require.Equal(t, tc.maxFeePerGas, fee, tc.name)
+			case tc.expFeeIsFloor:
+				require.Equal(t, params.MinGasPrice, fee, tc.name)
+			case tc.expFee != nil:
+				require.Equal(t, tc.expFee(), fee, tc.name)
+			default:
+				require.True(t, fee.GT(params.BaseFee), tc.name)
+			}
+
+			require.Equal(t, fee, nw.App.FeeMarketKeeper.GetDynamicBaseFeePerGas(ctx), tc.name)
+		})
+	}
+}
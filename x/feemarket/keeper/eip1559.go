@@ -8,6 +8,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ethereum/go-ethereum/common/math"
+
+	"github.com/cosmos/evm/x/feemarket/types"
 )
 
 // CalculateBaseFee calculates the base fee for the current block. This is calculated at EndBlock
@@ -23,15 +25,6 @@ func (k Keeper) CalculateBaseFee(ctx sdk.Context) sdkmath.LegacyDec {
 		return sdkmath.LegacyDec{}
 	}
 
-	consParams := ctx.ConsensusParams()
-
-	// If the current block is the first EIP-1559 block, return the base fee
-	// defined in the parameters (DefaultBaseFee if it hasn't been changed by
-	// governance).
-	if ctx.BlockHeight() == params.EnableHeight {
-		return params.BaseFee
-	}
-
 	// get the block gas used and the base fee values for the parent block.
 	// NOTE: this is not the parent's base fee but the current block's base fee,
 	// as it is retrieved from the transient store, which is committed to the
@@ -45,6 +38,35 @@ func (k Keeper) CalculateBaseFee(ctx sdk.Context) sdkmath.LegacyDec {
 		return sdkmath.LegacyDec{}
 	}
 
+	// Under the EMA algorithm, the adjustment is computed against a smoothed,
+	// persistent rolling average of gas used rather than the raw parent block
+	// gas used. This dampens the impact a single congested or idle block has
+	// on the base fee. The EMA is advanced here since this is the only place
+	// CalculateBaseFee is invoked from (EndBlock), mirroring how
+	// CalculateBlockGasWanted above also updates state as a side effect.
+	if params.BaseFeeAlgorithm == types.BaseFeeAlgorithm_EMA {
+		parentGasUsed = k.UpdateGasUsedEMA(ctx, parentGasUsed, params.EMAWindow)
+	}
+
+	isActivationBlock := ctx.BlockHeight() == params.EnableHeight
+	return k.computeBaseFee(ctx, params, isActivationBlock, parentBaseFee, parentGasUsed)
+}
+
+// computeBaseFee is the pure EIP-1559 adjustment math shared by
+// CalculateBaseFee (which feeds it the real, state-advancing gas-used
+// figure) and PeekPendingBaseFee (which feeds it a read-only peek at the same
+// figure). It has no side effects: no store writes, no events, no telemetry.
+//
+// isActivationBlock tracks whether this is the first EIP-1559 block. Like
+// go-ethereum's VerifyEIP1559Header, the parent gas limit at that block
+// predates the elasticity multiplier (blocks weren't sized with headroom
+// for a gas target yet), so it is used directly as the gas target instead
+// of being divided by ElasticityMultiplier. From the next block onward the
+// parent gas limit already has that headroom baked in, so dividing by
+// ElasticityMultiplier recovers the target as usual.
+func (k Keeper) computeBaseFee(ctx sdk.Context, params types.Params, isActivationBlock bool, parentBaseFee sdkmath.LegacyDec, parentGasUsed uint64) sdkmath.LegacyDec {
+	consParams := ctx.ConsensusParams()
+
 	gasLimit := sdkmath.NewIntFromUint64(math.MaxUint64)
 
 	// NOTE: a MaxGas equal to -1 means that block gas is unlimited
@@ -52,15 +74,21 @@ func (k Keeper) CalculateBaseFee(ctx sdk.Context) sdkmath.LegacyDec {
 		gasLimit = sdkmath.NewInt(consParams.Block.MaxGas)
 	}
 
-	// CONTRACT: ElasticityMultiplier cannot be 0 as it's checked in the params
-	// validation
-	parentGasTargetInt := gasLimit.Quo(sdkmath.NewIntFromUint64(uint64(params.ElasticityMultiplier)))
+	// CONTRACT: elasticityMultiplier is never 0 - Params validation rejects a
+	// 0 ElasticityMultiplier, and elasticityMultiplier() itself falls back to
+	// Params whenever the chainConfigProvider reports 0.
+	elasticityMultiplier := k.elasticityMultiplier(ctx, params)
+
+	parentGasTargetInt := gasLimit
+	if !isActivationBlock {
+		parentGasTargetInt = gasLimit.Quo(sdkmath.NewIntFromUint64(uint64(elasticityMultiplier)))
+	}
 	if !parentGasTargetInt.IsUint64() {
 		return sdkmath.LegacyDec{}
 	}
 
 	parentGasTarget := parentGasTargetInt.Uint64()
-	baseFeeChangeDenominator := sdkmath.NewIntFromUint64(uint64(params.BaseFeeChangeDenominator))
+	baseFeeChangeDenominator := sdkmath.NewIntFromUint64(uint64(k.baseFeeChangeDenominator(ctx, params)))
 
 	// If the parent gasUsed is the same as the target, the baseFee remains
 	// unchanged.
@@ -98,28 +126,82 @@ func (k Keeper) CalculateBaseFee(ctx sdk.Context) sdkmath.LegacyDec {
 	return sdkmath.LegacyMaxDec(parentBaseFee.Sub(baseFeeDelta), params.MinGasPrice)
 }
 
+// elasticityMultiplier returns the ElasticityMultiplier to use for the given
+// context, preferring the chainConfigProvider (if set) over the static
+// Params value so that it can vary by height via governance-scheduled
+// upgrades. Params validation guarantees params.ElasticityMultiplier is
+// never 0, but that validation doesn't reach a chainConfigProvider, whose
+// schedule is governance-configured independently - so a 0 from the
+// provider (e.g. a misconfigured upgrade height) is treated as
+// unconfigured and falls back to Params rather than propagating a value
+// that would divide-by-zero in computeBaseFee.
+func (k Keeper) elasticityMultiplier(ctx sdk.Context, params types.Params) uint32 {
+	if k.chainConfigProvider != nil {
+		if m := k.chainConfigProvider.ElasticityMultiplier(ctx); m != 0 {
+			return m
+		}
+	}
+	return params.ElasticityMultiplier
+}
+
+// baseFeeChangeDenominator returns the BaseFeeChangeDenominator to use for
+// the given context, preferring the chainConfigProvider (if set) over the
+// static Params value so that it can vary by height via governance-scheduled
+// upgrades. See elasticityMultiplier for why a 0 from the provider falls
+// back to Params instead of being trusted outright.
+func (k Keeper) baseFeeChangeDenominator(ctx sdk.Context, params types.Params) uint32 {
+	if k.chainConfigProvider != nil {
+		if d := k.chainConfigProvider.BaseFeeChangeDenominator(ctx); d != 0 {
+			return d
+		}
+	}
+	return params.BaseFeeChangeDenominator
+}
+
 // CalculateBlockGasWanted calculates the block gas wanted based on the current block's gas usage
 // and applies the minimum gas multiplier to prevent base fee manipulation.
 func (k Keeper) CalculateBlockGasWanted(ctx sdk.Context) (uint64, error) {
-	if ctx.BlockGasMeter() == nil {
-		err := errors.New("block gas meter is nil when setting block gas wanted")
+	updatedGasWanted, err := k.peekBlockGasWanted(ctx)
+	if err != nil {
 		k.Logger(ctx).Error(err.Error())
 		return 0, err
 	}
 
+	k.SetBlockGasWanted(ctx, updatedGasWanted)
+
+	defer func() {
+		telemetry.SetGauge(float32(updatedGasWanted), "feemarket", "block_gas")
+	}()
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"block_gas",
+		sdk.NewAttribute("height", fmt.Sprintf("%d", ctx.BlockHeight())),
+		sdk.NewAttribute("amount", fmt.Sprintf("%d", updatedGasWanted)),
+	))
+
+	k.emitGasSaturation(ctx, updatedGasWanted)
+
+	return updatedGasWanted, nil
+}
+
+// peekBlockGasWanted is the pure, side-effect-free computation behind
+// CalculateBlockGasWanted: gasWanted = max(gasWanted * MinGasMultiplier, gasUsed).
+// It neither persists the block gas wanted nor emits events/telemetry, so it
+// is safe to call from a read-only path like PeekPendingBaseFee.
+func (k Keeper) peekBlockGasWanted(ctx sdk.Context) (uint64, error) {
+	if ctx.BlockGasMeter() == nil {
+		return 0, errors.New("block gas meter is nil when setting block gas wanted")
+	}
+
 	gasWanted := sdkmath.NewIntFromUint64(k.GetTransientGasWanted(ctx))
 	gasUsed := sdkmath.NewIntFromUint64(ctx.BlockGasMeter().GasConsumedToLimit())
 
 	if !gasWanted.IsInt64() {
-		err := fmt.Errorf("integer overflow by integer type conversion. Gas wanted > MaxInt64. Gas wanted: %s", gasWanted)
-		k.Logger(ctx).Error(err.Error())
-		return 0, err
+		return 0, fmt.Errorf("integer overflow by integer type conversion. Gas wanted > MaxInt64. Gas wanted: %s", gasWanted)
 	}
 
 	if !gasUsed.IsInt64() {
-		err := fmt.Errorf("integer overflow by integer type conversion. Gas used > MaxInt64. Gas used: %s", gasUsed)
-		k.Logger(ctx).Error(err.Error())
-		return 0, err
+		return 0, fmt.Errorf("integer overflow by integer type conversion. Gas used > MaxInt64. Gas used: %s", gasUsed)
 	}
 
 	// to prevent BaseFee manipulation we limit the gasWanted so that
@@ -128,18 +210,80 @@ func (k Keeper) CalculateBlockGasWanted(ctx sdk.Context) (uint64, error) {
 	// more info here https://github.com/evmos/ethermint/pull/1105#discussion_r888798925
 	minGasMultiplier := k.GetParams(ctx).MinGasMultiplier
 	limitedGasWanted := sdkmath.LegacyNewDec(gasWanted.Int64()).Mul(minGasMultiplier)
-	updatedGasWanted := sdkmath.LegacyMaxDec(limitedGasWanted, sdkmath.LegacyNewDec(gasUsed.Int64())).TruncateInt().Uint64()
-	k.SetBlockGasWanted(ctx, updatedGasWanted)
+	return sdkmath.LegacyMaxDec(limitedGasWanted, sdkmath.LegacyNewDec(gasUsed.Int64())).TruncateInt().Uint64(), nil
+}
 
-	defer func() {
-		telemetry.SetGauge(float32(updatedGasWanted), "feemarket", "block_gas")
-	}()
+// gasSaturationRatio returns gasUsed/gasTarget for the current block, and
+// false if the target can't be determined (e.g. unlimited block gas).
+func (k Keeper) gasSaturationRatio(ctx sdk.Context, gasUsed uint64) (float64, bool) {
+	consParams := ctx.ConsensusParams()
+
+	gasLimit := sdkmath.NewIntFromUint64(math.MaxUint64)
+	if consParams.Block != nil && consParams.Block.MaxGas > -1 {
+		gasLimit = sdkmath.NewInt(consParams.Block.MaxGas)
+	}
+
+	params := k.GetParams(ctx)
+	targetInt := gasLimit.Quo(sdkmath.NewIntFromUint64(uint64(k.elasticityMultiplier(ctx, params))))
+	if !targetInt.IsUint64() || targetInt.IsZero() {
+		return 0, false
+	}
+
+	return float64(gasUsed) / float64(targetInt.Uint64()), true
+}
+
+// emitGasSaturation reports how saturated the block is relative to its gas
+// target (gasUsed/gasTarget) as a telemetry gauge and event, for wallets and
+// monitoring that want next-block fee signal beyond the raw base fee.
+func (k Keeper) emitGasSaturation(ctx sdk.Context, gasUsed uint64) {
+	saturation, ok := k.gasSaturationRatio(ctx, gasUsed)
+	if !ok {
+		return
+	}
+
+	telemetry.SetGauge(float32(saturation), "feemarket", "gas_saturation")
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
-		"block_gas",
-		sdk.NewAttribute("height", fmt.Sprintf("%d", ctx.BlockHeight())),
-		sdk.NewAttribute("amount", fmt.Sprintf("%d", updatedGasWanted)),
+		types.EventTypeFeeMarket,
+		sdk.NewAttribute(types.AttributeKeyGasSaturation, fmt.Sprintf("%f", saturation)),
 	))
+}
 
-	return updatedGasWanted, nil
+// PeekPendingBaseFee returns the base fee that would be produced by EndBlock
+// if the current block's gas usage stayed the same as it is right now. It
+// runs the same adjustment math as CalculateBaseFee, so it can be called
+// mid-block (e.g. from a gRPC query or eth_gasPrice) to give wallets a
+// real-time estimate without waiting for commit.
+//
+// Unlike CalculateBaseFee, this is genuinely read-only: it peeks at the block
+// gas wanted and, under the EMA algorithm, the gas-used EMA, instead of
+// persisting either one. It is therefore safe to call any number of times
+// per block, including more than once before EndBlock actually runs.
+//
+// Named distinctly from the Query/PendingBaseFee gRPC handler for the same
+// reason GetGasUsedEMA is named distinctly from the GasUsedEMA handler: Go
+// doesn't allow a type to declare two methods with the same name.
+func (k Keeper) PeekPendingBaseFee(ctx sdk.Context) sdkmath.LegacyDec {
+	params := k.GetParams(ctx)
+
+	if !params.IsBaseFeeEnabled(ctx.BlockHeight()) {
+		return sdkmath.LegacyDec{}
+	}
+
+	parentBaseFee := params.BaseFee
+	if parentBaseFee.IsNil() {
+		return sdkmath.LegacyDec{}
+	}
+
+	parentGasUsed, err := k.peekBlockGasWanted(ctx)
+	if err != nil {
+		return sdkmath.LegacyDec{}
+	}
+
+	if params.BaseFeeAlgorithm == types.BaseFeeAlgorithm_EMA {
+		parentGasUsed = k.peekGasUsedEMA(ctx, parentGasUsed, params.EMAWindow)
+	}
+
+	isActivationBlock := ctx.BlockHeight() == params.EnableHeight
+	return k.computeBaseFee(ctx, params, isActivationBlock, parentBaseFee, parentGasUsed)
 }
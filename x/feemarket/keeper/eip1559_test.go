@@ -10,6 +10,7 @@ import (
 	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
 
 	"github.com/cosmos/evm/testutil/integration/os/network"
+	"github.com/cosmos/evm/x/feemarket/types"
 
 	sdkmath "cosmossdk.io/math"
 	storetypes "cosmossdk.io/store/types"
@@ -41,12 +42,17 @@ func TestCalculateBaseFee(t *testing.T) {
 			nil,
 		},
 		{
+			// At the activation block the parent gas limit (100, from the
+			// MaxGas consensus param below) is used directly as the gas
+			// target instead of being divided by ElasticityMultiplier, per
+			// go-ethereum's VerifyEIP1559Header semantics. With no gas used,
+			// the base fee decreases from the target-gas-limit baseline.
 			"with BaseFee - initial EIP-1559 block",
 			false,
 			0,
 			0,
 			sdkmath.LegacyZeroDec(),
-			func() sdkmath.LegacyDec { return nw.App.FeeMarketKeeper.GetParams(ctx).BaseFee },
+			func() sdkmath.LegacyDec { return initialBaseFee.Sub(sdkmath.LegacyNewDec(109375000)) },
 		},
 		{
 			"with BaseFee - parent block wanted the same gas as its target (ElasticityMultiplier = 2)",
@@ -239,3 +245,124 @@ func TestCalculateBlockGasWanted(t *testing.T) {
 		})
 	}
 }
+
+// stubChainConfigProvider lets a test override ElasticityMultiplier and
+// BaseFeeChangeDenominator independently of Params, exercising the same
+// override path a height-varying EVM chain config would use.
+type stubChainConfigProvider struct {
+	elasticityMultiplier     uint32
+	baseFeeChangeDenominator uint32
+}
+
+func (s stubChainConfigProvider) ElasticityMultiplier(_ sdk.Context) uint32 {
+	return s.elasticityMultiplier
+}
+
+func (s stubChainConfigProvider) BaseFeeChangeDenominator(_ sdk.Context) uint32 {
+	return s.baseFeeChangeDenominator
+}
+
+// TestCalculateBaseFeeWithChainConfigProvider proves SetChainConfigProvider
+// is actually wired up: once set, CalculateBaseFee uses the provider's
+// ElasticityMultiplier/BaseFeeChangeDenominator instead of the static Params
+// values, which is what lets those two figures vary by height via a
+// governance-scheduled upgrade.
+func TestCalculateBaseFeeWithChainConfigProvider(t *testing.T) {
+	nw := network.NewUnitTestNetwork()
+	ctx := nw.GetContext()
+
+	params := nw.App.FeeMarketKeeper.GetParams(ctx)
+	params.ElasticityMultiplier = 2
+	params.BaseFeeChangeDenominator = 8
+	require.NoError(t, nw.App.FeeMarketKeeper.SetParams(ctx, params))
+
+	meter := storetypes.NewGasMeter(uint64(1000000000))
+	ctx = ctx.WithBlockGasMeter(meter)
+	ctx.BlockGasMeter().ConsumeGas(50, "test")
+	nw.App.FeeMarketKeeper.SetTransientBlockGasWanted(ctx, 50)
+
+	blockParams := tmproto.BlockParams{MaxGas: 100, MaxBytes: 10}
+	ctx = ctx.WithConsensusParams(tmproto.ConsensusParams{Block: &blockParams})
+
+	withoutProvider := nw.App.FeeMarketKeeper.PeekPendingBaseFee(ctx)
+
+	// a provider reporting a different ElasticityMultiplier moves the gas
+	// target, so the pending base fee calculation must change too.
+	nw.App.FeeMarketKeeper.SetChainConfigProvider(stubChainConfigProvider{
+		elasticityMultiplier:     4,
+		baseFeeChangeDenominator: 8,
+	})
+
+	withProvider := nw.App.FeeMarketKeeper.PeekPendingBaseFee(ctx)
+	require.NotEqual(t, withoutProvider, withProvider)
+}
+
+func TestPendingBaseFee(t *testing.T) {
+	nw := network.NewUnitTestNetwork()
+	ctx := nw.GetContext()
+
+	ctx = ctx.WithBlockHeight(1)
+	meter := storetypes.NewGasMeter(uint64(1000000000))
+	ctx = ctx.WithBlockGasMeter(meter)
+	ctx.BlockGasMeter().ConsumeGas(100, "test")
+	nw.App.FeeMarketKeeper.SetTransientBlockGasWanted(ctx, 100)
+
+	blockParams := tmproto.BlockParams{
+		MaxGas:   100,
+		MaxBytes: 10,
+	}
+	ctx = ctx.WithConsensusParams(tmproto.ConsensusParams{Block: &blockParams})
+
+	// the gas used doesn't change between the pending read and EndBlock, so
+	// the pending estimate must match what actually gets committed.
+	pending := nw.App.FeeMarketKeeper.PeekPendingBaseFee(ctx)
+
+	err := nw.App.FeeMarketKeeper.EndBlock(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, pending, nw.App.FeeMarketKeeper.GetBaseFee(ctx))
+}
+
+// TestPendingBaseFeeIsReadOnly proves that, under the EMA algorithm,
+// PeekPendingBaseFee never advances the persisted gas-used EMA (or the
+// persisted block gas wanted) no matter how many times it's called, and
+// that only EndBlock - called once - actually commits those changes.
+func TestPendingBaseFeeIsReadOnly(t *testing.T) {
+	nw := network.NewUnitTestNetwork()
+	ctx := nw.GetContext()
+
+	params := nw.App.FeeMarketKeeper.GetParams(ctx)
+	params.BaseFeeAlgorithm = types.BaseFeeAlgorithm_EMA
+	params.EMAWindow = 8
+	require.NoError(t, nw.App.FeeMarketKeeper.SetParams(ctx, params))
+	nw.App.FeeMarketKeeper.SetGasUsedEMA(ctx, 1000)
+
+	ctx = ctx.WithBlockHeight(1)
+	meter := storetypes.NewGasMeter(uint64(1000000000))
+	ctx = ctx.WithBlockGasMeter(meter)
+	ctx.BlockGasMeter().ConsumeGas(90, "test")
+	nw.App.FeeMarketKeeper.SetTransientBlockGasWanted(ctx, 90)
+
+	blockParams := tmproto.BlockParams{
+		MaxGas:   100,
+		MaxBytes: 10,
+	}
+	ctx = ctx.WithConsensusParams(tmproto.ConsensusParams{Block: &blockParams})
+
+	preEMA := nw.App.FeeMarketKeeper.GetGasUsedEMA(ctx)
+	preGasWanted := nw.App.FeeMarketKeeper.GetBlockGasWanted(ctx)
+
+	for i := 0; i < 3; i++ {
+		nw.App.FeeMarketKeeper.PeekPendingBaseFee(ctx)
+		require.Equal(t, preEMA, nw.App.FeeMarketKeeper.GetGasUsedEMA(ctx), "PeekPendingBaseFee must not advance the persisted EMA")
+		require.Equal(t, preGasWanted, nw.App.FeeMarketKeeper.GetBlockGasWanted(ctx), "PeekPendingBaseFee must not persist block gas wanted")
+	}
+
+	pending := nw.App.FeeMarketKeeper.PeekPendingBaseFee(ctx)
+
+	err := nw.App.FeeMarketKeeper.EndBlock(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, pending, nw.App.FeeMarketKeeper.GetBaseFee(ctx))
+	require.NotEqual(t, preEMA, nw.App.FeeMarketKeeper.GetGasUsedEMA(ctx), "EndBlock must advance the EMA exactly once")
+}
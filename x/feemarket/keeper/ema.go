@@ -0,0 +1,76 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/evm/x/feemarket/types"
+)
+
+// GetGasUsedEMA returns the persisted rolling gas-used exponential moving
+// average used by the BaseFeeAlgorithm_EMA algorithm. It returns 0 if the EMA
+// hasn't been seeded yet (e.g. prior to the first EndBlock after activation).
+func (k Keeper) GetGasUsedEMA(ctx sdk.Context) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.KeyPrefixGasUsedEMA)
+	if len(bz) == 0 {
+		return 0
+	}
+	return sdk.BigEndianToUint64(bz)
+}
+
+// SetGasUsedEMA persists the rolling gas-used EMA.
+func (k Keeper) SetGasUsedEMA(ctx sdk.Context, ema uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.KeyPrefixGasUsedEMA, sdk.Uint64ToBigEndian(ema))
+}
+
+// UpdateGasUsedEMA advances the rolling gas-used EMA by one observation,
+// persists it, and returns the updated value. It implements
+//
+//	ema = ema + (gasUsed - ema) / windowSize
+//
+// The first observation seeds the EMA with the raw gasUsed so that the base
+// fee calculation isn't skewed towards zero right after the algorithm is
+// switched on. A windowSize of 0 is treated as 1 (no smoothing).
+//
+// This mutates state and emits no ambiguity about that: it must only be
+// called once per block, from CalculateBaseFee at EndBlock. Anything that
+// wants to know what the EMA *would* become without advancing it (e.g. a
+// mid-block estimate) should use peekGasUsedEMA instead.
+func (k Keeper) UpdateGasUsedEMA(ctx sdk.Context, gasUsed uint64, windowSize uint32) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.KeyPrefixGasUsedEMA)
+	if len(bz) == 0 {
+		k.SetGasUsedEMA(ctx, gasUsed)
+		return gasUsed
+	}
+
+	newEMA := computeGasUsedEMA(sdk.BigEndianToUint64(bz), gasUsed, windowSize)
+	k.SetGasUsedEMA(ctx, newEMA)
+	return newEMA
+}
+
+// peekGasUsedEMA returns what the rolling gas-used EMA would become for the
+// given observation, without persisting it. Unlike UpdateGasUsedEMA, this is
+// safe to call any number of times per block.
+func (k Keeper) peekGasUsedEMA(ctx sdk.Context, gasUsed uint64, windowSize uint32) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.KeyPrefixGasUsedEMA)
+	if len(bz) == 0 {
+		return gasUsed
+	}
+	return computeGasUsedEMA(sdk.BigEndianToUint64(bz), gasUsed, windowSize)
+}
+
+// computeGasUsedEMA is the pure ema = ema + (gasUsed - ema) / windowSize
+// step shared by UpdateGasUsedEMA and peekGasUsedEMA.
+func computeGasUsedEMA(ema, gasUsed uint64, windowSize uint32) uint64 {
+	if windowSize == 0 {
+		windowSize = 1
+	}
+
+	if gasUsed >= ema {
+		return ema + (gasUsed-ema)/uint64(windowSize)
+	}
+	return ema - (ema-gasUsed)/uint64(windowSize)
+}
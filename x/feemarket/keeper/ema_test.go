@@ -0,0 +1,69 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/evm/testutil/integration/os/network"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestUpdateGasUsedEMA(t *testing.T) {
+	var (
+		nw  *network.UnitTestNetwork
+		ctx sdk.Context
+	)
+
+	testCases := []struct {
+		name       string
+		seed       uint64
+		gasUsed    uint64
+		windowSize uint32
+		expEMA     uint64
+	}{
+		{
+			"first observation seeds the EMA with the raw gas used",
+			0,
+			1000,
+			8,
+			1000,
+		},
+		{
+			"gas used above the EMA nudges it upward by 1/window",
+			1000,
+			9000,
+			8,
+			2000, // 1000 + (9000-1000)/8
+		},
+		{
+			"gas used below the EMA nudges it downward by 1/window",
+			1000,
+			0,
+			8,
+			875, // 1000 - (1000-0)/8
+		},
+		{
+			"a window size of 0 is treated as 1 (no smoothing)",
+			1000,
+			5000,
+			0,
+			5000,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			nw = network.NewUnitTestNetwork()
+			ctx = nw.GetContext()
+
+			if tc.seed != 0 {
+				nw.App.FeeMarketKeeper.SetGasUsedEMA(ctx, tc.seed)
+			}
+
+			ema := nw.App.FeeMarketKeeper.UpdateGasUsedEMA(ctx, tc.gasUsed, tc.windowSize)
+			require.Equal(t, tc.expEMA, ema, tc.name)
+			require.Equal(t, tc.expEMA, nw.App.FeeMarketKeeper.GetGasUsedEMA(ctx), tc.name)
+		})
+	}
+}
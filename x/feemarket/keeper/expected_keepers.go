@@ -0,0 +1,26 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ChainConfigProvider lets params that are conceptually part of the EVM chain
+// config (and may change at a governance-scheduled upgrade height, mirroring
+// go-ethereum's config.ElasticityMultiplier()/config.BaseFeeChangeDenominator())
+// override the feemarket's own Params for a given height. When unset, the
+// feemarket keeper falls back to its own Params fields.
+type ChainConfigProvider interface {
+	ElasticityMultiplier(ctx sdk.Context) uint32
+	BaseFeeChangeDenominator(ctx sdk.Context) uint32
+}
+
+// SetChainConfigProvider wires an optional ChainConfigProvider into the
+// keeper. It is expected to be called once at app wiring time (e.g. with the
+// EVM keeper, once it implements ChainConfigProvider) right after
+// NewKeeper; leaving it unset keeps the keeper's behavior as-is, reading
+// ElasticityMultiplier and BaseFeeChangeDenominator straight from Params.
+// See TestCalculateBaseFeeWithChainConfigProvider for the override behavior
+// this unlocks.
+func (k *Keeper) SetChainConfigProvider(provider ChainConfigProvider) {
+	k.chainConfigProvider = provider
+}
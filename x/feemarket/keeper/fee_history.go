@@ -0,0 +1,223 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/evm/x/feemarket/types"
+)
+
+// EVMKeeper is the subset of the EVM keeper that the feemarket keeper needs
+// in order to compute eth_feeHistory reward percentiles, which require
+// iterating the EVM transactions included in a block.
+type EVMKeeper interface {
+	// EffectivePriorityFees returns the effective priority fee (i.e. the
+	// tip actually paid to the proposer) of every EVM transaction included
+	// at the given height, ordered ascending.
+	EffectivePriorityFees(ctx sdk.Context, height int64) ([]sdkmath.Int, error)
+}
+
+// FeeHistoryResult mirrors the shape go-ethereum's eth_feeHistory RPC method
+// returns, so the JSON-RPC layer can forward it with no reshaping.
+type FeeHistoryResult struct {
+	OldestBlock   int64
+	BaseFeePerGas []sdkmath.LegacyDec
+	GasUsedRatio  []float64
+	Reward        [][]sdkmath.Int
+}
+
+// recordFeeHistory appends the current block's gas-used ratio, and the base
+// fee EndBlock just calculated, to the ring buffer, then prunes entries
+// older than FeeHistoryWindow blocks. It is called from EndBlock, after
+// SetBaseFee, so GetBaseFeeAt/FeeHistory see a consistent view once the
+// block commits.
+//
+// baseFee is the fee that will be *charged* to transactions in the next
+// block (see the NOTE in CalculateBaseFee), so it's recorded under height+1,
+// not the current height - otherwise GetBaseFeeAt(height) would return the
+// fee for height+1 and no entry would ever exist for the fee actually paid
+// in the current block.
+func (k Keeper) recordFeeHistory(ctx sdk.Context, baseFee sdkmath.LegacyDec) {
+	height := ctx.BlockHeight()
+	feeHeight := height + 1
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.BaseFeeHistoryKey(feeHeight), []byte(baseFee.String()))
+
+	gasUsed := ctx.BlockGasMeter().GasConsumedToLimit()
+	if ratio, ok := k.gasSaturationRatio(ctx, gasUsed); ok {
+		store.Set(types.GasUsedRatioHistoryKey(height), []byte(fmt.Sprintf("%f", ratio)))
+	}
+
+	window := int64(k.GetParams(ctx).FeeHistoryWindow)
+	if window <= 0 {
+		window = types.DefaultFeeHistoryWindow
+	}
+
+	// BaseFeeHistoryKey entries are keyed by feeHeight (height+1) while
+	// GasUsedRatioHistoryKey entries are keyed by height, so each ring
+	// buffer needs its own cutoff - otherwise FeeHistoryWindow would retain
+	// one more base fee entry than gas-used-ratio entry.
+	if gasUsedOldest := height - window; gasUsedOldest >= 0 {
+		store.Delete(types.GasUsedRatioHistoryKey(gasUsedOldest))
+	}
+
+	baseFeeOldest := feeHeight - window
+	if baseFeeOldest < 0 {
+		return
+	}
+	store.Delete(types.BaseFeeHistoryKey(baseFeeOldest))
+	k.setOldestFeeHistoryHeight(ctx, baseFeeOldest+1)
+}
+
+// getOldestFeeHistoryHeight returns the oldest base fee height still
+// retained in the ring buffer, or 0 if nothing has been pruned yet.
+// FeeHistory clamps its requested range to this floor instead of
+// hard-erroring on heights that have aged out of FeeHistoryWindow.
+func (k Keeper) getOldestFeeHistoryHeight(ctx sdk.Context) int64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.KeyPrefixOldestFeeHistoryHeight)
+	if len(bz) == 0 {
+		return 0
+	}
+	return int64(sdk.BigEndianToUint64(bz))
+}
+
+// setOldestFeeHistoryHeight persists the oldest base fee height still
+// retained in the ring buffer.
+func (k Keeper) setOldestFeeHistoryHeight(ctx sdk.Context, height int64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.KeyPrefixOldestFeeHistoryHeight, sdk.Uint64ToBigEndian(uint64(height)))
+}
+
+// GetBaseFeeAt returns the base fee recorded for the given height, and false
+// if it's outside the retained FeeHistoryWindow (or predates this feature).
+func (k Keeper) GetBaseFeeAt(ctx sdk.Context, height int64) (sdkmath.LegacyDec, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.BaseFeeHistoryKey(height))
+	if len(bz) == 0 {
+		return sdkmath.LegacyDec{}, false
+	}
+
+	fee, err := sdkmath.LegacyNewDecFromStr(string(bz))
+	if err != nil {
+		return sdkmath.LegacyDec{}, false
+	}
+	return fee, true
+}
+
+// GetGasUsedRatioAt returns the gasUsed/gasTarget ratio recorded for the
+// given height, and false if it's outside the retained FeeHistoryWindow.
+func (k Keeper) GetGasUsedRatioAt(ctx sdk.Context, height int64) (float64, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GasUsedRatioHistoryKey(height))
+	if len(bz) == 0 {
+		return 0, false
+	}
+
+	var ratio float64
+	if _, err := fmt.Sscanf(string(bz), "%f", &ratio); err != nil {
+		return 0, false
+	}
+	return ratio, true
+}
+
+// FeeHistory returns the same shape as go-ethereum's eth_feeHistory: the
+// oldest block covered, a baseFeePerGas array of length blockCount+1 (the
+// trailing entry is the *next* unconfirmed block's base fee), a
+// gasUsedRatio array of length blockCount, and a reward matrix of
+// len(rewardPercentiles) columns per block. Reward percentiles are computed
+// by delegating to the EVM keeper, which has the transactions for each
+// block; feemarket only owns the base fee / gas-used history.
+//
+// If part of the requested range has aged out of FeeHistoryWindow,
+// oldestBlock is clamped to the oldest height still retained and the
+// returned arrays cover a correspondingly shorter range - mirroring
+// go-ethereum's behavior for pruned nodes - rather than erroring out the
+// entire call.
+func (k Keeper) FeeHistory(ctx sdk.Context, evmKeeper EVMKeeper, blockCount uint64, newestBlock int64, rewardPercentiles []float64) (*FeeHistoryResult, error) {
+	if blockCount == 0 {
+		return nil, fmt.Errorf("block count must be greater than 0")
+	}
+
+	oldestBlock := newestBlock - int64(blockCount) + 1
+	if oldestBlock < 0 {
+		oldestBlock = 0
+	}
+	if retained := k.getOldestFeeHistoryHeight(ctx); oldestBlock < retained {
+		oldestBlock = retained
+	}
+
+	result := &FeeHistoryResult{
+		OldestBlock:   oldestBlock,
+		BaseFeePerGas: make([]sdkmath.LegacyDec, 0, blockCount+1),
+		GasUsedRatio:  make([]float64, 0, blockCount),
+	}
+
+	for height := oldestBlock; height <= newestBlock; height++ {
+		baseFee, ok := k.GetBaseFeeAt(ctx, height)
+		if !ok {
+			// a leading height with no record (e.g. height 0, whose base fee
+			// no EndBlock ever recorded) moves OldestBlock past it; a gap
+			// after recorded heights stops here and returns what's
+			// available rather than failing the whole query.
+			if len(result.BaseFeePerGas) == 0 {
+				result.OldestBlock = height + 1
+				continue
+			}
+			break
+		}
+		result.BaseFeePerGas = append(result.BaseFeePerGas, baseFee)
+
+		ratio, _ := k.GetGasUsedRatioAt(ctx, height)
+		result.GasUsedRatio = append(result.GasUsedRatio, ratio)
+
+		if len(rewardPercentiles) > 0 {
+			fees, err := evmKeeper.EffectivePriorityFees(ctx, height)
+			if err != nil {
+				return nil, err
+			}
+			result.Reward = append(result.Reward, rewardsAtPercentiles(fees, rewardPercentiles))
+		}
+	}
+
+	// the trailing entry is the base fee for newestBlock+1. If that height
+	// is already recorded in the ring buffer, use it - it's the actual fee,
+	// not an estimate. Only fall back to PeekPendingBaseFee when newestBlock
+	// is the live tip and newestBlock+1 hasn't happened yet, which is the
+	// only case where a "pending" estimate is meaningful at all.
+	if nextBaseFee, ok := k.GetBaseFeeAt(ctx, newestBlock+1); ok {
+		result.BaseFeePerGas = append(result.BaseFeePerGas, nextBaseFee)
+	} else {
+		result.BaseFeePerGas = append(result.BaseFeePerGas, k.PeekPendingBaseFee(ctx))
+	}
+
+	return result, nil
+}
+
+// rewardsAtPercentiles picks, for each requested percentile, the priority
+// fee of the transaction at that position in the (already-ascending) fees
+// slice. An empty block reports zero reward for every percentile.
+func rewardsAtPercentiles(fees []sdkmath.Int, percentiles []float64) []sdkmath.Int {
+	rewards := make([]sdkmath.Int, len(percentiles))
+	if len(fees) == 0 {
+		for i := range rewards {
+			rewards[i] = sdkmath.ZeroInt()
+		}
+		return rewards
+	}
+
+	for i, p := range percentiles {
+		idx := int(p / 100 * float64(len(fees)-1))
+		if idx < 0 {
+			idx = 0
+		}
+		if idx >= len(fees) {
+			idx = len(fees) - 1
+		}
+		rewards[i] = fees[idx]
+	}
+	return rewards
+}
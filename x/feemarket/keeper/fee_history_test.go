@@ -0,0 +1,148 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+
+	"github.com/cosmos/evm/testutil/integration/os/network"
+	"github.com/cosmos/evm/x/feemarket/keeper"
+
+	sdkmath "cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// stubEVMKeeper returns a fixed set of effective priority fees regardless of
+// height, which is enough to exercise FeeHistory's reward percentile logic.
+type stubEVMKeeper struct {
+	fees []sdkmath.Int
+}
+
+func (s stubEVMKeeper) EffectivePriorityFees(_ sdk.Context, _ int64) ([]sdkmath.Int, error) {
+	return s.fees, nil
+}
+
+func endBlockAt(t *testing.T, nw *network.UnitTestNetwork, height int64, gasUsed uint64) sdk.Context {
+	t.Helper()
+
+	ctx := nw.GetContext().WithBlockHeight(height)
+	meter := storetypes.NewGasMeter(uint64(1000000000))
+	ctx = ctx.WithBlockGasMeter(meter)
+	ctx.BlockGasMeter().ConsumeGas(gasUsed, "test")
+	nw.App.FeeMarketKeeper.SetTransientBlockGasWanted(ctx, gasUsed)
+
+	blockParams := tmproto.BlockParams{MaxGas: 100, MaxBytes: 10}
+	ctx = ctx.WithConsensusParams(tmproto.ConsensusParams{Block: &blockParams})
+
+	require.NoError(t, nw.App.FeeMarketKeeper.EndBlock(ctx))
+	return ctx
+}
+
+func TestFeeHistory(t *testing.T) {
+	nw := network.NewUnitTestNetwork()
+
+	// EndBlock(N) records the base fee that will be charged in block N+1, so
+	// heights 0..3 are needed to have a base fee on record for blocks 1..3.
+	var lastCtx sdk.Context
+	for height := int64(0); height <= 3; height++ {
+		lastCtx = endBlockAt(t, nw, height, 60)
+	}
+
+	evmKeeper := stubEVMKeeper{fees: []sdkmath.Int{
+		sdkmath.NewInt(1), sdkmath.NewInt(2), sdkmath.NewInt(3), sdkmath.NewInt(4),
+	}}
+
+	history, err := nw.App.FeeMarketKeeper.FeeHistory(lastCtx, evmKeeper, 3, 3, []float64{0, 50, 100})
+	require.NoError(t, err)
+
+	require.Equal(t, int64(1), history.OldestBlock)
+	require.Len(t, history.BaseFeePerGas, 4) // blockCount + 1 (trailing pending entry)
+	require.Len(t, history.GasUsedRatio, 3)
+	require.Len(t, history.Reward, 3)
+	require.Equal(t, []sdkmath.Int{sdkmath.NewInt(1), sdkmath.NewInt(2), sdkmath.NewInt(4)}, history.Reward[0])
+
+	// asking for a range that reaches outside the retained window clamps
+	// OldestBlock instead of erroring the whole call - the caller gets back
+	// whatever is actually still on record.
+	clamped, err := nw.App.FeeMarketKeeper.FeeHistory(lastCtx, evmKeeper, 1000, 3, nil)
+	require.NoError(t, err)
+	require.Equal(t, history.OldestBlock, clamped.OldestBlock)
+	require.Equal(t, history.BaseFeePerGas, clamped.BaseFeePerGas)
+}
+
+// TestFeeHistoryHistoricalNewestBlock proves that, when newestBlock is
+// behind the chain tip, the trailing BaseFeePerGas entry is the recorded fee
+// for newestBlock+1 from the ring buffer rather than today's live pending
+// estimate - those two only happen to agree when newestBlock is the tip.
+func TestFeeHistoryHistoricalNewestBlock(t *testing.T) {
+	nw := network.NewUnitTestNetwork()
+
+	var lastCtx sdk.Context
+	for height := int64(0); height <= 5; height++ {
+		lastCtx = endBlockAt(t, nw, height, 60)
+	}
+
+	evmKeeper := stubEVMKeeper{fees: nil}
+
+	history, err := nw.App.FeeMarketKeeper.FeeHistory(lastCtx, evmKeeper, 2, 2, nil)
+	require.NoError(t, err)
+
+	recordedNextFee, ok := nw.App.FeeMarketKeeper.GetBaseFeeAt(lastCtx, 3)
+	require.True(t, ok)
+	require.Equal(t, recordedNextFee, history.BaseFeePerGas[len(history.BaseFeePerGas)-1])
+
+	// the live pending estimate reflects block 5's gas usage, not block 2's,
+	// so it must not have leaked into a query for newestBlock == 2.
+	live := nw.App.FeeMarketKeeper.PeekPendingBaseFee(lastCtx)
+	if !live.Equal(recordedNextFee) {
+		require.NotEqual(t, live, history.BaseFeePerGas[len(history.BaseFeePerGas)-1])
+	}
+}
+
+func TestFeeHistoryPrunedWindow(t *testing.T) {
+	nw := network.NewUnitTestNetwork()
+	ctx := nw.GetContext()
+
+	params := nw.App.FeeMarketKeeper.GetParams(ctx)
+	params.FeeHistoryWindow = 2
+	require.NoError(t, nw.App.FeeMarketKeeper.SetParams(ctx, params))
+
+	var lastCtx sdk.Context
+	for height := int64(0); height <= 5; height++ {
+		lastCtx = endBlockAt(t, nw, height, 60)
+	}
+
+	evmKeeper := stubEVMKeeper{fees: []sdkmath.Int{sdkmath.NewInt(1)}}
+
+	// blocks 1..5 were requested, but a FeeHistoryWindow of 2 only retains
+	// the base fee charged in block 5 (and the not-yet-queryable block 6) -
+	// OldestBlock is clamped up to 5 instead of erroring out the whole call.
+	history, err := nw.App.FeeMarketKeeper.FeeHistory(lastCtx, evmKeeper, 5, 5, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), history.OldestBlock)
+	require.Len(t, history.BaseFeePerGas, 2) // height 5 plus the trailing pending entry
+	require.Len(t, history.GasUsedRatio, 1)
+}
+
+func TestGetBaseFeeAtAndGasUsedRatioAt(t *testing.T) {
+	nw := network.NewUnitTestNetwork()
+	// EndBlock(0) records the base fee charged in block 1 and the gas-used
+	// ratio observed at height 0 itself.
+	ctx := endBlockAt(t, nw, 0, 30)
+
+	baseFee, ok := nw.App.FeeMarketKeeper.GetBaseFeeAt(ctx, 1)
+	require.True(t, ok)
+	require.Equal(t, nw.App.FeeMarketKeeper.GetBaseFee(ctx), baseFee)
+
+	ratio, ok := nw.App.FeeMarketKeeper.GetGasUsedRatioAt(ctx, 0)
+	require.True(t, ok)
+	require.InDelta(t, 0.6, ratio, 0.001) // 30 gas used / target 50 (100 MaxGas / ElasticityMultiplier 2)
+
+	_, ok = nw.App.FeeMarketKeeper.GetBaseFeeAt(ctx, 999)
+	require.False(t, ok)
+}
+
+var _ = keeper.EVMKeeper(stubEVMKeeper{})
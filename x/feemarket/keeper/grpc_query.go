@@ -0,0 +1,39 @@
+package keeper
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/evm/x/feemarket/types"
+)
+
+var _ types.QueryServer = Keeper{}
+
+// GasUsedEMA implements the Query/GasUsedEMA gRPC method, returning the
+// keeper's persisted rolling gas-used exponential moving average used by the
+// BaseFeeAlgorithm_EMA algorithm.
+func (k Keeper) GasUsedEMA(goCtx context.Context, req *types.QueryGasUsedEMARequest) (*types.QueryGasUsedEMAResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	return &types.QueryGasUsedEMAResponse{GasUsedEma: k.GetGasUsedEMA(ctx)}, nil
+}
+
+// PendingBaseFee implements the Query/PendingBaseFee gRPC method, returning
+// the base fee that would be produced by EndBlock if the current block's gas
+// usage stayed the same as it is right now. See the Keeper.PeekPendingBaseFee
+// doc comment for why this is safe to call mid-block without side effects.
+func (k Keeper) PendingBaseFee(goCtx context.Context, req *types.QueryPendingBaseFeeRequest) (*types.QueryPendingBaseFeeResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	return &types.QueryPendingBaseFeeResponse{PendingBaseFee: k.PeekPendingBaseFee(ctx)}, nil
+}
@@ -0,0 +1,58 @@
+package keeper
+
+import (
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/evm/x/feemarket/types"
+)
+
+// Migrator is a wrapper for the feemarket keeper used only for state
+// migrations, following the standard cosmos-sdk module migration pattern.
+type Migrator struct {
+	keeper Keeper
+}
+
+// NewMigrator returns a new Migrator for the feemarket module.
+func NewMigrator(keeper Keeper) Migrator {
+	return Migrator{keeper: keeper}
+}
+
+// Migrate1to2 backfills BaseFeeAlgorithm and EMAWindow on params stored
+// before those fields existed. Existing chains keep their current adjustment
+// behavior unchanged: they default to BaseFeeAlgorithm_EIP1559, which is the
+// only algorithm that could have been running before this migration.
+func (m Migrator) Migrate1to2(ctx sdk.Context) error {
+	params := m.keeper.GetParams(ctx)
+	params.BaseFeeAlgorithm = types.BaseFeeAlgorithm_EIP1559
+	params.EMAWindow = types.DefaultEMAWindow
+	return m.keeper.SetParams(ctx, params)
+}
+
+// Migrate2to3 backfills MaximumFeePerGas and TargetGasUsedPerBlock on params
+// stored before those fields existed. MaximumFeePerGas defaults to unbounded
+// (nil) so AdjustDynamicBaseFeePerGas's behavior is unchanged for chains that
+// haven't opted into a cap; TargetGasUsedPerBlock defaults to
+// DefaultTargetGasUsedPerBlock since a zero target would make
+// AdjustDynamicBaseFeePerGas error on every call.
+func (m Migrator) Migrate2to3(ctx sdk.Context) error {
+	params := m.keeper.GetParams(ctx)
+	params.MaximumFeePerGas = sdkmath.LegacyDec{}
+	params.TargetGasUsedPerBlock = types.DefaultTargetGasUsedPerBlock
+	return m.keeper.SetParams(ctx, params)
+}
+
+// Migrate3to4 backfills FeeHistoryWindow on params stored before it existed,
+// and seeds the ring buffer's oldest-height marker at the height after the
+// current one (the first height whose base fee EndBlock will record) so
+// FeeHistory doesn't report historical coverage for blocks up to the one
+// this migration ran in (none were ever recorded).
+func (m Migrator) Migrate3to4(ctx sdk.Context) error {
+	params := m.keeper.GetParams(ctx)
+	params.FeeHistoryWindow = types.DefaultFeeHistoryWindow
+	if err := m.keeper.SetParams(ctx, params); err != nil {
+		return err
+	}
+	m.keeper.setOldestFeeHistoryHeight(ctx, ctx.BlockHeight()+1)
+	return nil
+}
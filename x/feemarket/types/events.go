@@ -0,0 +1,16 @@
+package types
+
+// Event attribute keys for the fee-dynamics telemetry events emitted by
+// EndBlock and CalculateBlockGasWanted (see keeper/abci.go, keeper/eip1559.go).
+// EventTypeFeeMarket and AttributeKeyBaseFee are pre-existing and defined
+// elsewhere in the module.
+const (
+	// AttributeKeyBaseFeeDelta is the signed change applied to the base fee
+	// this block (new - previous).
+	AttributeKeyBaseFeeDelta = "base_fee_delta"
+	// AttributeKeyMinGasPriceFloorHit reports whether MinGasPrice is
+	// currently constraining the base fee.
+	AttributeKeyMinGasPriceFloorHit = "min_gas_price_floor_hit"
+	// AttributeKeyGasSaturation is the block's gasUsed/gasTarget ratio.
+	AttributeKeyGasSaturation = "gas_saturation"
+)
@@ -0,0 +1,39 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// KeyPrefixGasUsedEMA is the KVStore key the persisted rolling gas-used EMA
+// is stored under (see Keeper.GetGasUsedEMA/SetGasUsedEMA).
+var KeyPrefixGasUsedEMA = []byte{0x10}
+
+// KeyPrefixDynamicBaseFee is the transient store key the in-block dynamic
+// base fee is stored under (see Keeper.GetDynamicBaseFeePerGas/
+// setDynamicBaseFeePerGas). It lives in the transient store, so it's
+// discarded at Commit and reconciled by the next EndBlock.
+var KeyPrefixDynamicBaseFee = []byte{0x11}
+
+// Key prefixes backing the eth_feeHistory ring buffer (see
+// Keeper.recordFeeHistory/FeeHistory). Base fees and gas-used ratios are
+// recorded under different prefixes because they're keyed by different
+// heights: BaseFeeHistoryKey(feeHeight) where feeHeight = height+1 (the
+// height the fee will actually be charged at), GasUsedRatioHistoryKey(height)
+// under the height the gas was observed at.
+var (
+	KeyPrefixBaseFeeHistory         = []byte{0x12}
+	KeyPrefixGasUsedRatioHistory    = []byte{0x13}
+	KeyPrefixOldestFeeHistoryHeight = []byte{0x14}
+)
+
+// BaseFeeHistoryKey returns the KVStore key the base fee charged at height
+// is recorded under.
+func BaseFeeHistoryKey(height int64) []byte {
+	return append(KeyPrefixBaseFeeHistory, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GasUsedRatioHistoryKey returns the KVStore key the gasUsed/gasTarget ratio
+// observed at height is recorded under.
+func GasUsedRatioHistoryKey(height int64) []byte {
+	return append(KeyPrefixGasUsedRatioHistory, sdk.Uint64ToBigEndian(uint64(height))...)
+}
@@ -0,0 +1,198 @@
+package types
+
+import (
+	"fmt"
+
+	sdkmath "cosmossdk.io/math"
+)
+
+// BaseFeeAlgorithm selects how CalculateBaseFee adjusts the base fee between
+// blocks.
+type BaseFeeAlgorithm int32
+
+const (
+	// BaseFeeAlgorithm_EIP1559 reacts to the immediately prior block's gas
+	// used, exactly as go-ethereum's EIP-1559 does.
+	BaseFeeAlgorithm_EIP1559 BaseFeeAlgorithm = 0
+	// BaseFeeAlgorithm_EMA smooths the adjustment across several blocks by
+	// computing it against a rolling exponential moving average of gas used
+	// (see Keeper.UpdateGasUsedEMA) instead of the raw parent gas used, so a
+	// single congested or idle block doesn't whipsaw the fee.
+	BaseFeeAlgorithm_EMA BaseFeeAlgorithm = 1
+)
+
+// String returns a human-readable name for the algorithm, used in error
+// messages and CLI/query output.
+func (a BaseFeeAlgorithm) String() string {
+	switch a {
+	case BaseFeeAlgorithm_EIP1559:
+		return "EIP1559"
+	case BaseFeeAlgorithm_EMA:
+		return "EMA"
+	default:
+		return fmt.Sprintf("BaseFeeAlgorithm(%d)", int32(a))
+	}
+}
+
+// Default param values.
+const (
+	// DefaultEMAWindow is the window size used to smooth gasUsedEMA when
+	// BaseFeeAlgorithm_EMA is selected: roughly the last 8 blocks.
+	DefaultEMAWindow uint32 = 8
+	// DefaultTargetGasUsedPerBlock is the gas-used target
+	// AdjustDynamicBaseFeePerGas measures congestion against by default.
+	DefaultTargetGasUsedPerBlock uint64 = 15_000_000
+	// DefaultFeeHistoryWindow is the number of blocks of base-fee/gas-used
+	// history retained by default.
+	DefaultFeeHistoryWindow uint64 = 1024
+)
+
+// Params defines the EIP-1559 fee market module parameters.
+type Params struct {
+	// NoBaseFee disables the EIP-1559 base fee calculation entirely; when
+	// true, CalculateBaseFee and PeekPendingBaseFee always return nil.
+	NoBaseFee bool
+	// BaseFee is the current base fee, in and persisted across blocks by
+	// SetBaseFee.
+	BaseFee sdkmath.LegacyDec
+	// EnableHeight is the block height at which the base fee calculation
+	// activates.
+	EnableHeight int64
+	// ElasticityMultiplier bounds how far a block's gas used can run above
+	// its gas target; the gas target is GasLimit / ElasticityMultiplier,
+	// except at the EnableHeight block, where the full GasLimit is the target.
+	ElasticityMultiplier uint32
+	// BaseFeeChangeDenominator divides the per-block base fee adjustment:
+	// the fee moves by prior fee * (gasUsed-gasTarget)/gasTarget /
+	// BaseFeeChangeDenominator, which is at most 1/BaseFeeChangeDenominator
+	// of the prior fee only while gasUsed stays within twice the target.
+	BaseFeeChangeDenominator uint32
+	// MinGasPrice is the floor below which the base fee never drops.
+	MinGasPrice sdkmath.LegacyDec
+	// MinGasMultiplier bounds how far the gas figure CalculateBlockGasWanted
+	// reports can fall below the block's gas wanted: it reports
+	// max(gasWanted * MinGasMultiplier, gasUsed), to prevent base fee
+	// manipulation via gas wanted far above the gas actually used.
+	MinGasMultiplier sdkmath.LegacyDec
+
+	// BaseFeeAlgorithm selects between the immediate-prior-block EIP-1559
+	// adjustment and the EMA-smoothed alternative.
+	BaseFeeAlgorithm BaseFeeAlgorithm
+	// EMAWindow is the window size for the rolling gasUsedEMA used by
+	// BaseFeeAlgorithm_EMA; only meaningful when that algorithm is selected.
+	EMAWindow uint32
+
+	// MaximumFeePerGas caps AdjustDynamicBaseFeePerGas's mid-block fee
+	// increases. A nil value means unbounded, matching MinGasPrice's use of
+	// the zero value to mean "no floor beyond zero".
+	MaximumFeePerGas sdkmath.LegacyDec
+	// TargetGasUsedPerBlock is the gas-used target AdjustDynamicBaseFeePerGas
+	// measures congestion against; it must be nonzero; see
+	// Keeper.AdjustDynamicBaseFeePerGas.
+	TargetGasUsedPerBlock uint64
+
+	// FeeHistoryWindow is the number of recent blocks' base fees and
+	// gas-used ratios retained in the keeper's ring buffer for FeeHistory.
+	FeeHistoryWindow uint64
+}
+
+// NewParams creates a new Params instance.
+func NewParams(
+	noBaseFee bool,
+	baseFee sdkmath.LegacyDec,
+	enableHeight int64,
+	elasticityMultiplier, baseFeeChangeDenominator uint32,
+	minGasPrice, minGasMultiplier sdkmath.LegacyDec,
+	baseFeeAlgorithm BaseFeeAlgorithm,
+	emaWindow uint32,
+	maximumFeePerGas sdkmath.LegacyDec,
+	targetGasUsedPerBlock uint64,
+	feeHistoryWindow uint64,
+) Params {
+	return Params{
+		NoBaseFee:                noBaseFee,
+		BaseFee:                  baseFee,
+		EnableHeight:             enableHeight,
+		ElasticityMultiplier:     elasticityMultiplier,
+		BaseFeeChangeDenominator: baseFeeChangeDenominator,
+		MinGasPrice:              minGasPrice,
+		MinGasMultiplier:         minGasMultiplier,
+		BaseFeeAlgorithm:         baseFeeAlgorithm,
+		EMAWindow:                emaWindow,
+		MaximumFeePerGas:         maximumFeePerGas,
+		TargetGasUsedPerBlock:    targetGasUsedPerBlock,
+		FeeHistoryWindow:         feeHistoryWindow,
+	}
+}
+
+// DefaultParams returns default feemarket module parameters.
+func DefaultParams() Params {
+	return NewParams(
+		false,
+		sdkmath.LegacyNewDec(1_000_000_000),
+		0,
+		2,
+		8,
+		sdkmath.LegacyZeroDec(),
+		sdkmath.LegacyNewDecWithPrec(5, 1), // 0.5
+		BaseFeeAlgorithm_EIP1559,
+		DefaultEMAWindow,
+		sdkmath.LegacyDec{}, // unbounded
+		DefaultTargetGasUsedPerBlock,
+		DefaultFeeHistoryWindow,
+	)
+}
+
+// IsBaseFeeEnabled returns whether the base fee calculation is active at the
+// given height.
+func (p Params) IsBaseFeeEnabled(height int64) bool {
+	return !p.NoBaseFee && height >= p.EnableHeight
+}
+
+// Validate performs basic validation of the feemarket parameters.
+func (p Params) Validate() error {
+	if p.BaseFee.IsNil() {
+		return fmt.Errorf("base fee cannot be nil")
+	}
+	if p.BaseFee.IsNegative() {
+		return fmt.Errorf("base fee cannot be negative: %s", p.BaseFee)
+	}
+	if p.MinGasPrice.IsNil() || p.MinGasPrice.IsNegative() {
+		return fmt.Errorf("min gas price cannot be negative: %s", p.MinGasPrice)
+	}
+	if p.MinGasMultiplier.IsNil() || p.MinGasMultiplier.IsNegative() {
+		return fmt.Errorf("min gas multiplier cannot be negative: %s", p.MinGasMultiplier)
+	}
+	if p.ElasticityMultiplier == 0 {
+		return fmt.Errorf("elasticity multiplier cannot be 0")
+	}
+	if p.BaseFeeChangeDenominator == 0 {
+		return fmt.Errorf("base fee change denominator cannot be 0")
+	}
+	if p.EnableHeight < 0 {
+		return fmt.Errorf("enable height cannot be negative: %d", p.EnableHeight)
+	}
+	if !p.MaximumFeePerGas.IsNil() && p.MaximumFeePerGas.IsNegative() {
+		return fmt.Errorf("maximum fee per gas cannot be negative: %s", p.MaximumFeePerGas)
+	}
+	if !p.MaximumFeePerGas.IsNil() && p.MaximumFeePerGas.LT(p.MinGasPrice) {
+		return fmt.Errorf("maximum fee per gas (%s) cannot be less than min gas price (%s)", p.MaximumFeePerGas, p.MinGasPrice)
+	}
+	if p.TargetGasUsedPerBlock == 0 {
+		return fmt.Errorf("target gas used per block cannot be 0")
+	}
+	return validateBaseFeeAlgorithm(p.BaseFeeAlgorithm)
+}
+
+// validateBaseFeeAlgorithm checks that algorithm is one of the known
+// BaseFeeAlgorithm values. EMAWindow is intentionally not range-checked
+// beyond its uint32 type: a value of 0 is already handled as "no smoothing"
+// by computeGasUsedEMA, so there's no invalid window size to reject.
+func validateBaseFeeAlgorithm(algorithm BaseFeeAlgorithm) error {
+	switch algorithm {
+	case BaseFeeAlgorithm_EIP1559, BaseFeeAlgorithm_EMA:
+		return nil
+	default:
+		return fmt.Errorf("invalid base fee algorithm: %s", algorithm)
+	}
+}
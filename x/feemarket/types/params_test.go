@@ -0,0 +1,104 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdkmath "cosmossdk.io/math"
+
+	"github.com/cosmos/evm/x/feemarket/types"
+)
+
+func TestDefaultParamsValidate(t *testing.T) {
+	require.NoError(t, types.DefaultParams().Validate())
+}
+
+func TestParamsValidate(t *testing.T) {
+	valid := types.DefaultParams()
+
+	testCases := []struct {
+		name      string
+		malleate  func(types.Params) types.Params
+		expErrMsg string
+	}{
+		{
+			"nil base fee",
+			func(p types.Params) types.Params { p.BaseFee = sdkmath.LegacyDec{}; return p },
+			"base fee cannot be nil",
+		},
+		{
+			"negative base fee",
+			func(p types.Params) types.Params { p.BaseFee = sdkmath.LegacyNewDec(-1); return p },
+			"base fee cannot be negative",
+		},
+		{
+			"negative min gas price",
+			func(p types.Params) types.Params { p.MinGasPrice = sdkmath.LegacyNewDec(-1); return p },
+			"min gas price cannot be negative",
+		},
+		{
+			"zero elasticity multiplier",
+			func(p types.Params) types.Params { p.ElasticityMultiplier = 0; return p },
+			"elasticity multiplier cannot be 0",
+		},
+		{
+			"zero base fee change denominator",
+			func(p types.Params) types.Params { p.BaseFeeChangeDenominator = 0; return p },
+			"base fee change denominator cannot be 0",
+		},
+		{
+			"negative enable height",
+			func(p types.Params) types.Params { p.EnableHeight = -1; return p },
+			"enable height cannot be negative",
+		},
+		{
+			"invalid base fee algorithm",
+			func(p types.Params) types.Params { p.BaseFeeAlgorithm = types.BaseFeeAlgorithm(99); return p },
+			"invalid base fee algorithm",
+		},
+		{
+			"negative maximum fee per gas",
+			func(p types.Params) types.Params { p.MaximumFeePerGas = sdkmath.LegacyNewDec(-1); return p },
+			"maximum fee per gas cannot be negative",
+		},
+		{
+			"maximum fee per gas below min gas price",
+			func(p types.Params) types.Params {
+				p.MinGasPrice = sdkmath.LegacyNewDec(10)
+				p.MaximumFeePerGas = sdkmath.LegacyNewDec(5)
+				return p
+			},
+			"cannot be less than min gas price",
+		},
+		{
+			"zero target gas used per block",
+			func(p types.Params) types.Params { p.TargetGasUsedPerBlock = 0; return p },
+			"target gas used per block cannot be 0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.malleate(valid).Validate()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.expErrMsg)
+		})
+	}
+}
+
+// TestZeroFeeHistoryWindowIsValid documents that, unlike ElasticityMultiplier
+// and BaseFeeChangeDenominator, a zero FeeHistoryWindow is accepted:
+// recordFeeHistory treats it as "unset" and falls back to
+// DefaultFeeHistoryWindow rather than disabling history retention.
+func TestZeroFeeHistoryWindowIsValid(t *testing.T) {
+	params := types.DefaultParams()
+	params.FeeHistoryWindow = 0
+	require.NoError(t, params.Validate())
+}
+
+func TestBaseFeeAlgorithmString(t *testing.T) {
+	require.Equal(t, "EIP1559", types.BaseFeeAlgorithm_EIP1559.String())
+	require.Equal(t, "EMA", types.BaseFeeAlgorithm_EMA.String())
+	require.Contains(t, types.BaseFeeAlgorithm(99).String(), "99")
+}
@@ -0,0 +1,39 @@
+package types
+
+import (
+	"context"
+
+	sdkmath "cosmossdk.io/math"
+)
+
+// QueryServer defines the gRPC query service for the feemarket module.
+type QueryServer interface {
+	// GasUsedEMA returns the persisted rolling gas-used EMA used by the
+	// BaseFeeAlgorithm_EMA algorithm.
+	GasUsedEMA(context.Context, *QueryGasUsedEMARequest) (*QueryGasUsedEMAResponse, error)
+
+	// PendingBaseFee returns the base fee that would be produced by
+	// EndBlock if the current block's gas usage stayed the same as it is
+	// right now, without waiting for commit.
+	PendingBaseFee(context.Context, *QueryPendingBaseFeeRequest) (*QueryPendingBaseFeeResponse, error)
+}
+
+// QueryGasUsedEMARequest is the request type for the Query/GasUsedEMA RPC
+// method.
+type QueryGasUsedEMARequest struct{}
+
+// QueryGasUsedEMAResponse is the response type for the Query/GasUsedEMA RPC
+// method.
+type QueryGasUsedEMAResponse struct {
+	GasUsedEma uint64
+}
+
+// QueryPendingBaseFeeRequest is the request type for the
+// Query/PendingBaseFee RPC method.
+type QueryPendingBaseFeeRequest struct{}
+
+// QueryPendingBaseFeeResponse is the response type for the
+// Query/PendingBaseFee RPC method.
+type QueryPendingBaseFeeResponse struct {
+	PendingBaseFee sdkmath.LegacyDec
+}